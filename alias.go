@@ -0,0 +1,81 @@
+package flag
+
+import (
+	f "flag"
+	"strings"
+)
+
+// flagAliases holds, for each canonical flag name, the aliases registered alongside it, in
+// declaration order. Used by PrintDefaults to render "-name, -alias type" groupings.
+var flagAliases = map[string][]string{}
+
+// aliasValue is a flag.Value that delegates to another flag's Value, letting an alias name
+// be registered in the stdlib FlagSet without duplicating the canonical flag's storage or
+// parsing logic.
+type aliasValue struct {
+	target f.Value
+}
+
+func (a *aliasValue) Set(s string) error {
+	return a.target.Set(s)
+}
+
+func (a *aliasValue) String() string {
+	if a.target == nil {
+		return ""
+	}
+	return a.target.String()
+}
+
+// boolFlag mirrors the unexported interface stdlib's flag package uses to decide whether a
+// flag can be given without an explicit value, e.g. "-v" instead of "-v=true".
+type boolFlag interface {
+	f.Value
+	IsBoolFlag() bool
+}
+
+// IsBoolFlag reports whether the aliased flag is a bool flag, so a boolean alias (e.g. "-v"
+// for "-verbose,v") can be used as a standalone switch the same way its canonical flag can.
+func (a *aliasValue) IsBoolFlag() bool {
+	bf, ok := a.target.(boolFlag)
+	return ok && bf.IsBoolFlag()
+}
+
+// splitNames splits a comma-delimited name argument (e.g. "config,c") into its canonical
+// name, used for the env/config lookup and as the primary flag, and its aliases.
+func splitNames(name string) (canonical string, aliases []string) {
+	parts := strings.Split(name, ",")
+	canonical = strings.TrimSpace(parts[0])
+	for _, part := range parts[1:] {
+		aliases = append(aliases, strings.TrimSpace(part))
+	}
+	return canonical, aliases
+}
+
+// registerAliases registers each alias against the same underlying value as canonical, and
+// records the grouping so PrintDefaults can render them on a single line.
+func registerAliases(canonical string, aliases []string) {
+	if len(aliases) == 0 {
+		return
+	}
+	flagAliases[canonical] = append(flagAliases[canonical], aliases...)
+	target := f.Lookup(canonical)
+	if target == nil {
+		return
+	}
+	for _, alias := range aliases {
+		f.Var(&aliasValue{target: target.Value}, alias, "")
+	}
+}
+
+// isAlias reports whether name was registered as an alias of another flag.
+func isAlias(name string) bool {
+	for _, aliases := range flagAliases {
+		for _, alias := range aliases {
+			if alias == name {
+				return true
+			}
+		}
+	}
+	return false
+}
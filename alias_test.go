@@ -0,0 +1,47 @@
+package flag
+
+import (
+	"flag"
+	"os"
+	"testing"
+)
+
+func resetAliasState() {
+	flagAliases = map[string][]string{}
+}
+
+func TestBoolAliasUsableAsStandaloneSwitch(t *testing.T) {
+	oldArgs := os.Args
+	defer func() { os.Args = oldArgs }()
+	resetAliasState()
+	resetConfigState()
+	SetEnvPrefix("")
+
+	os.Args = []string{"prog", "-v"}
+	flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+
+	p := Bool("verbose,v", false, "verbose output")
+	Parse()
+
+	if !*p {
+		t.Fatalf("expected -v to set verbose to true")
+	}
+}
+
+func TestStringAliasWritesThroughToCanonical(t *testing.T) {
+	oldArgs := os.Args
+	defer func() { os.Args = oldArgs }()
+	resetAliasState()
+	resetConfigState()
+	SetEnvPrefix("")
+
+	os.Args = []string{"prog", "-h", "fromalias"}
+	flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+
+	p := String("host,h", "", "a host name")
+	Parse()
+
+	if *p != "fromalias" {
+		t.Fatalf("expected alias to write through to canonical flag, got %q", *p)
+	}
+}
@@ -0,0 +1,227 @@
+package flag
+
+import (
+	"bufio"
+	"encoding/json"
+	f "flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	configFlagName = "config"
+	configValues   = map[string]string{}
+	configOnce     sync.Once
+)
+
+// SetConfigFlagName changes the name of the reserved flag used to point at a config file.
+// Must be called before the first flag is defined, as that is when the config file is loaded.
+func SetConfigFlagName(name string) {
+	configFlagName = name
+}
+
+// configValue is the flag.Value registered for the config flag itself. Its Set is a no-op:
+// the config file is loaded eagerly by ensureConfigLoaded, scanning os.Args directly, since
+// that has to happen before the flags it seeds their defaults for are even defined.
+// Registering it is still required so stdlib's f.Parse does not reject "-config=..." with
+// "flag provided but not defined".
+type configValue struct{}
+
+func (configValue) String() string   { return "" }
+func (configValue) Set(string) error { return nil }
+
+// ensureConfigLoaded registers the config flag and, if it is present in os.Args, loads the
+// file it points to into configValues. It runs at most once, triggered by whichever happens
+// first: the first xxxFromConfig call made by a flag-definer function, or a call to Parse -
+// in the normal "define flags, then Parse" usage pattern that is the former.
+func ensureConfigLoaded() {
+	configOnce.Do(func() {
+		f.Var(configValue{}, configFlagName, "load flag defaults from a config file (.json, or key = value with optional [section] headers)")
+		path := configFilePath(os.Args[1:])
+		if path == "" {
+			return
+		}
+		if err := loadConfigFile(path); err != nil {
+			fmt.Fprintf(os.Stderr, "flag: failed to load config file %q: %v\n", path, err)
+		}
+	})
+}
+
+// configFilePath extracts the value of the config flag from args, supporting both
+// "-config=path"/"--config=path" and "-config path"/"--config path" forms.
+func configFilePath(args []string) string {
+	eq := []string{"-" + configFlagName + "=", "--" + configFlagName + "="}
+	for i, arg := range args {
+		for _, prefix := range eq {
+			if strings.HasPrefix(arg, prefix) {
+				return strings.TrimPrefix(arg, prefix)
+			}
+		}
+		if (arg == "-"+configFlagName || arg == "--"+configFlagName) && i+1 < len(args) {
+			return args[i+1]
+		}
+	}
+	return ""
+}
+
+// loadConfigFile loads path into configValues, auto-detecting the format from its extension.
+// Nested keys (JSON objects, INI sections) are flattened to dashed flag names, e.g.
+// server.port becomes server-port.
+func loadConfigFile(path string) error {
+	if strings.ToLower(filepath.Ext(path)) == ".json" {
+		return loadConfigJSON(path)
+	}
+	return loadConfigINI(path)
+}
+
+func loadConfigJSON(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var raw map[string]interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	flattenJSON("", raw)
+	return nil
+}
+
+func flattenJSON(prefix string, raw map[string]interface{}) {
+	for key, value := range raw {
+		name := key
+		if prefix != "" {
+			name = prefix + "." + key
+		}
+		switch v := value.(type) {
+		case map[string]interface{}:
+			flattenJSON(name, v)
+		case []interface{}:
+			parts := make([]string, 0, len(v))
+			for _, item := range v {
+				parts = append(parts, fmt.Sprintf("%v", item))
+			}
+			configValues[dashedConfigKey(name)] = strings.Join(parts, ",")
+		default:
+			configValues[dashedConfigKey(name)] = fmt.Sprintf("%v", value)
+		}
+	}
+}
+
+// loadConfigINI parses a simple INI-style file of "key = value" lines, with optional
+// "[section]" headers that namespace the keys that follow.
+func loadConfigINI(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	section := ""
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			section = strings.TrimSpace(line[1 : len(line)-1])
+			continue
+		}
+		parts := strings.SplitN(line, "=", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		key := strings.TrimSpace(parts[0])
+		if section != "" {
+			key = section + "." + key
+		}
+		configValues[dashedConfigKey(key)] = strings.TrimSpace(parts[1])
+	}
+	return scanner.Err()
+}
+
+func dashedConfigKey(name string) string {
+	return strings.ReplaceAll(name, ".", "-")
+}
+
+// boolFromConfig returns the config-file value for name. If not found, or not loaded, returns value.
+func boolFromConfig(name string, value bool) bool {
+	ensureConfigLoaded()
+	val, found := configValues[name]
+	if !found {
+		return value
+	}
+	return val == "true"
+}
+
+// stringFromConfig returns the config-file value for name. If not found, or not loaded, returns value.
+func stringFromConfig(name string, value string) string {
+	ensureConfigLoaded()
+	val, found := configValues[name]
+	if !found {
+		return value
+	}
+	return val
+}
+
+// int64FromConfig returns the parsed config-file value for name. On error, or if not found, returns value.
+func int64FromConfig(name string, value int64) int64 {
+	ensureConfigLoaded()
+	val, found := configValues[name]
+	if !found {
+		return value
+	}
+	i, err := strconv.ParseInt(val, 0, 64)
+	if err != nil {
+		return value
+	}
+	return i
+}
+
+// uint64FromConfig returns the parsed config-file value for name. On error, or if not found, returns value.
+func uint64FromConfig(name string, value uint64) uint64 {
+	ensureConfigLoaded()
+	val, found := configValues[name]
+	if !found {
+		return value
+	}
+	i, err := strconv.ParseUint(val, 0, 64)
+	if err != nil {
+		return value
+	}
+	return i
+}
+
+// float64FromConfig returns the parsed config-file value for name. On error, or if not found, returns value.
+func float64FromConfig(name string, value float64) float64 {
+	ensureConfigLoaded()
+	val, found := configValues[name]
+	if !found {
+		return value
+	}
+	fl, err := strconv.ParseFloat(val, 64)
+	if err != nil {
+		return value
+	}
+	return fl
+}
+
+// durationFromConfig returns the parsed config-file value for name. On error, or if not found, returns value.
+func durationFromConfig(name string, value time.Duration) time.Duration {
+	ensureConfigLoaded()
+	val, found := configValues[name]
+	if !found {
+		return value
+	}
+	dur, err := time.ParseDuration(val)
+	if err != nil {
+		return value
+	}
+	return dur
+}
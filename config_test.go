@@ -0,0 +1,151 @@
+package flag
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+// resetConfigState clears the config-loading singleton so each test case starts as if the
+// package had just been imported.
+func resetConfigState() {
+	configOnce = sync.Once{}
+	configValues = map[string]string{}
+	configFlagName = "config"
+}
+
+func TestConfigFlagIsRegistered(t *testing.T) {
+	oldArgs := os.Args
+	defer func() { os.Args = oldArgs }()
+	resetConfigState()
+	SetEnvPrefix("")
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.ini")
+	if err := os.WriteFile(path, []byte("greeting = fromconfig\n"), 0o644); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+
+	os.Args = []string{"prog", "-config=" + path, "-greeting=fromcli"}
+	flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+
+	p := String("greeting", "default", "a greeting")
+	Parse()
+
+	if *p != "fromcli" {
+		t.Fatalf("expected explicit command-line value to win, got %q", *p)
+	}
+}
+
+func TestConfigPrecedence(t *testing.T) {
+	oldArgs := os.Args
+	defer func() { os.Args = oldArgs }()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.ini")
+	if err := os.WriteFile(path, []byte("greeting = fromconfig\n"), 0o644); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+
+	t.Run("config value seeds the default", func(t *testing.T) {
+		resetConfigState()
+		SetEnvPrefix("")
+		os.Unsetenv("GREETING")
+		os.Args = []string{"prog", "-config=" + path}
+		flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+
+		p := String("greeting", "default", "a greeting")
+		Parse()
+
+		if *p != "fromconfig" {
+			t.Fatalf("expected config value, got %q", *p)
+		}
+	})
+
+	t.Run("env overrides config", func(t *testing.T) {
+		resetConfigState()
+		SetEnvPrefix("")
+		os.Setenv("GREETING", "fromenv")
+		defer os.Unsetenv("GREETING")
+		os.Args = []string{"prog", "-config=" + path}
+		flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+
+		p := String("greeting", "default", "a greeting")
+		Parse()
+
+		if *p != "fromenv" {
+			t.Fatalf("expected env value to override config, got %q", *p)
+		}
+	})
+
+	t.Run("command line overrides env and config", func(t *testing.T) {
+		resetConfigState()
+		SetEnvPrefix("")
+		os.Setenv("GREETING", "fromenv")
+		defer os.Unsetenv("GREETING")
+		os.Args = []string{"prog", "-config=" + path, "-greeting=fromcli"}
+		flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+
+		p := String("greeting", "default", "a greeting")
+		Parse()
+
+		if *p != "fromcli" {
+			t.Fatalf("expected command-line value to override env and config, got %q", *p)
+		}
+	})
+}
+
+func TestConfigJSONFlattensNestedKeys(t *testing.T) {
+	oldArgs := os.Args
+	defer func() { os.Args = oldArgs }()
+	resetConfigState()
+	SetEnvPrefix("")
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.json")
+	body := `{"greeting": "fromconfig", "server": {"port": "8080"}}`
+	if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+
+	os.Args = []string{"prog", "-config=" + path}
+	flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+
+	greeting := String("greeting", "default", "a greeting")
+	port := String("server-port", "default", "a server port")
+	Parse()
+
+	if *greeting != "fromconfig" {
+		t.Fatalf("expected top-level key, got %q", *greeting)
+	}
+	if *port != "8080" {
+		t.Fatalf("expected nested key server.port flattened to server-port, got %q", *port)
+	}
+}
+
+func TestSetConfigFlagNameUsesCustomFlag(t *testing.T) {
+	oldArgs := os.Args
+	defer func() { os.Args = oldArgs }()
+	resetConfigState()
+	defer func() { configFlagName = "config" }()
+	SetEnvPrefix("")
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.ini")
+	if err := os.WriteFile(path, []byte("greeting = fromconfig\n"), 0o644); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+
+	SetConfigFlagName("conf")
+	os.Args = []string{"prog", "-conf=" + path}
+	flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+
+	p := String("greeting", "default", "a greeting")
+	Parse()
+
+	if *p != "fromconfig" {
+		t.Fatalf("expected config value loaded via the renamed flag, got %q", *p)
+	}
+}
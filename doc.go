@@ -0,0 +1,69 @@
+package flag
+
+import (
+	f "flag"
+	"strings"
+)
+
+// DocGenerationFlag describes a registered flag in a form downstream tooling (man page or
+// markdown generators) can consume directly, without reflecting over the package's
+// definer functions.
+type DocGenerationFlag interface {
+	// GetName returns the canonical flag name, without the leading dash.
+	GetName() string
+	// GetUsage returns the flag's usage string, with the back quotes around any placeholder removed.
+	GetUsage() string
+	// GetDefaultText returns the flag's default value, formatted the way PrintDefaults shows it.
+	GetDefaultText() string
+	// GetEnvVars returns the environment variables consulted as a fallback for this flag.
+	GetEnvVars() []string
+	// GetPlaceholder returns the back-quoted placeholder from the usage string, e.g.
+	// "directory" for `search `directory` for include files``, or "" if none was given.
+	GetPlaceholder() string
+}
+
+// docFlag is the DocGenerationFlag backing every flag registered through this package.
+type docFlag struct {
+	flag *f.Flag
+}
+
+func (d *docFlag) GetName() string {
+	return d.flag.Name
+}
+
+func (d *docFlag) GetUsage() string {
+	_, usage := f.UnquoteUsage(d.flag)
+	return usage
+}
+
+func (d *docFlag) GetDefaultText() string {
+	return d.flag.DefValue
+}
+
+func (d *docFlag) GetEnvVars() []string {
+	return []string{envNameForFlagName(d.flag.Name)}
+}
+
+func (d *docFlag) GetPlaceholder() string {
+	usage := d.flag.Usage
+	if i := strings.IndexByte(usage, '`'); i >= 0 {
+		if j := strings.IndexByte(usage[i+1:], '`'); j >= 0 {
+			return usage[i+1 : i+1+j]
+		}
+	}
+	return ""
+}
+
+// Flags returns the DocGenerationFlag for every canonical flag registered through this
+// package. Aliases are omitted, since they share the canonical flag's storage, usage, and
+// environment variable.
+func Flags() []DocGenerationFlag {
+	var result []DocGenerationFlag
+	f.VisitAll(func(fl *f.Flag) {
+		if isAlias(fl.Name) {
+			return
+		}
+		result = append(result, &docFlag{flag: fl})
+	})
+	return result
+}
@@ -0,0 +1,69 @@
+package flag
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestFlagsSkipsAliasesAndReportsEnvVar(t *testing.T) {
+	oldArgs := os.Args
+	defer func() { os.Args = oldArgs }()
+	resetAliasState()
+	resetConfigState()
+	SetEnvPrefix("")
+
+	os.Args = []string{"prog"}
+	flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+
+	String("host,h", "", "a `name` to connect to")
+	Parse()
+
+	docFlags := Flags()
+	var got DocGenerationFlag
+	for _, df := range docFlags {
+		if df.GetName() == "host" {
+			got = df
+		}
+		if df.GetName() == "h" {
+			t.Fatalf("expected alias %q to be omitted from Flags(), got it listed", "h")
+		}
+	}
+	if got == nil {
+		t.Fatalf("expected a doc flag named %q, got %v", "host", docFlags)
+	}
+	if got.GetPlaceholder() != "name" {
+		t.Fatalf("expected placeholder %q, got %q", "name", got.GetPlaceholder())
+	}
+	if want := []string{"HOST"}; got.GetEnvVars()[0] != want[0] {
+		t.Fatalf("expected env vars %v, got %v", want, got.GetEnvVars())
+	}
+}
+
+func TestPrintDefaultsIncludesEnvHintAndAliases(t *testing.T) {
+	oldArgs := os.Args
+	defer func() { os.Args = oldArgs }()
+	resetAliasState()
+	resetConfigState()
+	SetEnvPrefix("")
+
+	os.Args = []string{"prog"}
+	flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+
+	var buf bytes.Buffer
+	flag.CommandLine.SetOutput(&buf)
+
+	String("host,h", "", "a host name")
+	Parse()
+	PrintDefaults()
+
+	out := buf.String()
+	if !strings.Contains(out, "-host, -h") {
+		t.Fatalf("expected output to list the alias, got %q", out)
+	}
+	if !strings.Contains(out, "[$HOST]") {
+		t.Fatalf("expected output to include the env var hint, got %q", out)
+	}
+}
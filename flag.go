@@ -4,6 +4,7 @@ import (
 	f "flag"
 	"fmt"
 	"os"
+	"reflect"
 	"strconv"
 	"strings"
 	"time"
@@ -23,7 +24,8 @@ var (
 // happens anyway as the command line's error handling strategy is set to
 // ExitOnError.
 func Usage() {
-	f.Usage()
+	fmt.Fprintf(f.CommandLine.Output(), "Usage of %s:\n", os.Args[0])
+	PrintDefaults()
 }
 
 // SetEnvPrefix sets the prefix for environmental default values
@@ -55,13 +57,18 @@ func boolFromEnv(name string, value bool) bool {
 // Bool defines a bool flag with specified name, default value, and usage string.
 // The return value is the address of a bool variable that stores the value of the flag.
 func Bool(name string, value bool, usage string) *bool {
-	return f.Bool(name, boolFromEnv(name, value), usage)
+	canonical, aliases := splitNames(name)
+	p := f.Bool(canonical, boolFromEnv(canonical, boolFromConfig(canonical, value)), usage)
+	registerAliases(canonical, aliases)
+	return p
 }
 
 // BoolVar defines a bool flag with specified name, default value, and usage string.
 // The argument p points to a bool variable in which to store the value of the flag.
 func BoolVar(p *bool, name string, value bool, usage string) {
-	f.BoolVar(p, name, boolFromEnv(name, value), usage)
+	canonical, aliases := splitNames(name)
+	f.BoolVar(p, canonical, boolFromEnv(canonical, boolFromConfig(canonical, value)), usage)
+	registerAliases(canonical, aliases)
 }
 
 // durationFromEnv returns parsed duration from environment variable. On error returning default value
@@ -81,14 +88,19 @@ func durationFromEnv(name string, value time.Duration) time.Duration {
 // The return value is the address of a time.Duration variable that stores the value of the flag.
 // The flag accepts a value acceptable to time.ParseDuration.
 func Duration(name string, value time.Duration, usage string) *time.Duration {
-	return f.Duration(name, durationFromEnv(name, value), usage)
+	canonical, aliases := splitNames(name)
+	p := f.Duration(canonical, durationFromEnv(canonical, durationFromConfig(canonical, value)), usage)
+	registerAliases(canonical, aliases)
+	return p
 }
 
 // DurationVar defines a time.Duration flag with specified name, default value, and usage string.
 // The argument p points to a time.Duration variable in which to store the value of the flag.
 // The flag accepts a value acceptable to time.ParseDuration.
 func DurationVar(p *time.Duration, name string, value time.Duration, usage string) {
-	f.DurationVar(p, name, durationFromEnv(name, value), usage)
+	canonical, aliases := splitNames(name)
+	f.DurationVar(p, canonical, durationFromEnv(canonical, durationFromConfig(canonical, value)), usage)
+	registerAliases(canonical, aliases)
 }
 
 // float64FromEnv returns parsed float64 from environment variable. On error returning default value
@@ -107,13 +119,18 @@ func float64FromEnv(name string, value float64) float64 {
 // Float64 defines a float64 flag with specified name, default value, and usage string.
 // The return value is the address of a float64 variable that stores the value of the flag.
 func Float64(name string, value float64, usage string) *float64 {
-	return f.Float64(name, float64FromEnv(name, value), usage)
+	canonical, aliases := splitNames(name)
+	p := f.Float64(canonical, float64FromEnv(canonical, float64FromConfig(canonical, value)), usage)
+	registerAliases(canonical, aliases)
+	return p
 }
 
 // Float64Var defines a float64 flag with specified name, default value, and usage string.
 // The argument p points to a float64 variable in which to store the value of the flag.
 func Float64Var(p *float64, name string, value float64, usage string) {
-	f.Float64Var(p, name, float64FromEnv(name, value), usage)
+	canonical, aliases := splitNames(name)
+	f.Float64Var(p, canonical, float64FromEnv(canonical, float64FromConfig(canonical, value)), usage)
+	registerAliases(canonical, aliases)
 }
 
 // Func defines a flag with the specified name and usage string. Each time the flag is seen,
@@ -139,25 +156,35 @@ func int64FromEnv(name string, value int64) int64 {
 // Int defines an int flag with specified name, default value, and usage string.
 // The return value is the address of an int variable that stores the value of the flag.
 func Int(name string, value int, usage string) *int {
-	return f.Int(name, int(int64FromEnv(name, int64(value))), usage)
+	canonical, aliases := splitNames(name)
+	p := f.Int(canonical, int(int64FromEnv(canonical, int64FromConfig(canonical, int64(value)))), usage)
+	registerAliases(canonical, aliases)
+	return p
 }
 
 // Int64 defines an int64 flag with specified name, default value, and usage string.
 // The return value is the address of an int64 variable that stores the value of the flag.
 func Int64(name string, value int64, usage string) *int64 {
-	return f.Int64(name, int64FromEnv(name, value), usage)
+	canonical, aliases := splitNames(name)
+	p := f.Int64(canonical, int64FromEnv(canonical, int64FromConfig(canonical, value)), usage)
+	registerAliases(canonical, aliases)
+	return p
 }
 
 // Int64Var defines an int64 flag with specified name, default value, and usage string.
 // The argument p points to an int64 variable in which to store the value of the flag.
 func Int64Var(p *int64, name string, value int64, usage string) {
-	f.Int64Var(p, name, int64FromEnv(name, value), usage)
+	canonical, aliases := splitNames(name)
+	f.Int64Var(p, canonical, int64FromEnv(canonical, int64FromConfig(canonical, value)), usage)
+	registerAliases(canonical, aliases)
 }
 
 // IntVar defines an int flag with specified name, default value, and usage string.
 // The argument p points to an int variable in which to store the value of the flag.
 func IntVar(p *int, name string, value int, usage string) {
-	f.IntVar(p, name, int(int64FromEnv(name, int64(value))), usage)
+	canonical, aliases := splitNames(name)
+	f.IntVar(p, canonical, int(int64FromEnv(canonical, int64FromConfig(canonical, int64(value)))), usage)
+	registerAliases(canonical, aliases)
 }
 
 // NArg is the number of arguments remaining after flags have been processed.
@@ -171,8 +198,17 @@ func NFlag() int {
 }
 
 // Parse parses the command-line flags from os.Args[1:]. Must be called after all flags are defined and before flags are accessed by the program.
+// It first makes sure the config file referenced by the config flag, if any, has been loaded; see SetConfigFlagName.
+// Once stdlib parsing succeeds, it checks the constraints registered via MarkRequired, MutuallyExclusive, and
+// RequiresAll; if any is violated, it prints usage and exits with status 2.
 func Parse() {
+	ensureConfigLoaded()
 	f.Parse()
+	if err := validateConstraints(); err != nil {
+		fmt.Fprintln(f.CommandLine.Output(), err)
+		Usage()
+		os.Exit(2)
+	}
 }
 
 // Parsed reports whether the command-line flags have been parsed.
@@ -206,8 +242,68 @@ func Parsed() bool {
 //		search directory for include files.
 //
 // To change the destination for flag messages, call CommandLine.SetOutput.
+//
+// Flags defined with a comma-delimited name (e.g. "config,c") are rendered grouped on a
+// single line, e.g. "-config, -c string". Every flag also gets a trailing "[$ENV_NAME]"
+// hint naming the environment variable that serves as its fallback; see envNameForFlagName.
 func PrintDefaults() {
-	f.PrintDefaults()
+	f.VisitAll(func(fl *f.Flag) {
+		if isAlias(fl.Name) {
+			return
+		}
+		printFlagDefault(fl)
+	})
+}
+
+// printFlagDefault prints fl in the layout stdlib's PrintDefaults uses, additionally listing any
+// aliases registered alongside it. Unlike stdlib, the default value is printed unquoted for
+// string flags too.
+func printFlagDefault(fl *f.Flag) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "  -%s", fl.Name)
+	for _, alias := range flagAliases[fl.Name] {
+		fmt.Fprintf(&b, ", -%s", alias)
+	}
+
+	name, usage := f.UnquoteUsage(fl)
+	if len(name) > 0 {
+		b.WriteString(" ")
+		b.WriteString(name)
+	}
+	if b.Len() <= 4 {
+		b.WriteString("\t")
+	} else {
+		b.WriteString("\n    \t")
+	}
+	b.WriteString(strings.ReplaceAll(usage, "\n", "\n    \t"))
+
+	if !isZeroValue(fl) {
+		fmt.Fprintf(&b, " (default %v)", fl.DefValue)
+	}
+	fmt.Fprintf(&b, " [$%s]", envNameForFlagName(fl.Name))
+	fmt.Fprint(f.CommandLine.Output(), b.String(), "\n")
+}
+
+// isZeroValue reports whether fl's default value is the zero value for its type, mirroring
+// the check stdlib's PrintDefaults uses to decide whether to print a "(default ...)" clause.
+func isZeroValue(fl *f.Flag) (ok bool) {
+	typ := reflect.TypeOf(fl.Value)
+	var z reflect.Value
+	if typ.Kind() == reflect.Pointer {
+		z = reflect.New(typ.Elem())
+	} else {
+		z = reflect.Zero(typ)
+	}
+	zeroValue, isValue := z.Interface().(f.Value)
+	if !isValue {
+		return false
+	}
+	defer func() {
+		if recover() != nil {
+			ok = false
+		}
+	}()
+	return fl.DefValue == zeroValue.String()
 }
 
 // Set sets the value of the named command-line flag.
@@ -227,13 +323,18 @@ func stringFromEnv(name string, value string) string {
 // String defines a string flag with specified name, default value, and usage string.
 //The return value is the address of a string variable that stores the value of the flag.
 func String(name string, value string, usage string) *string {
-	return f.String(name, stringFromEnv(name, value), usage)
+	canonical, aliases := splitNames(name)
+	p := f.String(canonical, stringFromEnv(canonical, stringFromConfig(canonical, value)), usage)
+	registerAliases(canonical, aliases)
+	return p
 }
 
 // StringVar defines a string flag with specified name, default value, and usage string.
 // The argument p points to a string variable in which to store the value of the flag.
 func StringVar(p *string, name string, value string, usage string) {
-	f.StringVar(p, name, stringFromEnv(name, value), usage)
+	canonical, aliases := splitNames(name)
+	f.StringVar(p, canonical, stringFromEnv(canonical, stringFromConfig(canonical, value)), usage)
+	registerAliases(canonical, aliases)
 }
 
 // uint64FromEnv returns parsed int64 from environment variable. On error returning default value
@@ -252,25 +353,35 @@ func uint64FromEnv(name string, value uint64) uint64 {
 // Uint defines a uint flag with specified name, default value, and usage string.
 // The return value is the address of a uint variable that stores the value of the flag.
 func Uint(name string, value uint, usage string) *uint {
-	return f.Uint(name, uint(uint64FromEnv(name, uint64(value))), usage)
+	canonical, aliases := splitNames(name)
+	p := f.Uint(canonical, uint(uint64FromEnv(canonical, uint64FromConfig(canonical, uint64(value)))), usage)
+	registerAliases(canonical, aliases)
+	return p
 }
 
 // Uint64 defines a uint64 flag with specified name, default value, and usage string.
 // The return value is the address of a uint64 variable that stores the value of the flag.
 func Uint64(name string, value uint64, usage string) *uint64 {
-	return f.Uint64(name, uint64FromEnv(name, value), usage)
+	canonical, aliases := splitNames(name)
+	p := f.Uint64(canonical, uint64FromEnv(canonical, uint64FromConfig(canonical, value)), usage)
+	registerAliases(canonical, aliases)
+	return p
 }
 
 // Uint64Var defines a uint64 flag with specified name, default value, and usage string.
 //The argument p points to a uint64 variable in which to store the value of the flag.
 func Uint64Var(p *uint64, name string, value uint64, usage string) {
-	f.Uint64Var(p, name, uint64FromEnv(name, value), usage)
+	canonical, aliases := splitNames(name)
+	f.Uint64Var(p, canonical, uint64FromEnv(canonical, uint64FromConfig(canonical, value)), usage)
+	registerAliases(canonical, aliases)
 }
 
 // UintVar defines a uint flag with specified name, default value, and usage string.
 // The argument p points to a uint variable in which to store the value of the flag.
 func UintVar(p *uint, name string, value uint, usage string) {
-	f.Uint(name, uint(uint64FromEnv(name, uint64(value))), usage)
+	canonical, aliases := splitNames(name)
+	f.UintVar(p, canonical, uint(uint64FromEnv(canonical, uint64FromConfig(canonical, uint64(value)))), usage)
+	registerAliases(canonical, aliases)
 }
 
 // UnquoteUsage extracts a back-quoted name from the usage string for a flag and returns it and the un-quoted usage.
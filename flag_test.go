@@ -16,17 +16,17 @@ type verbTestCase struct {
 
 var testCasesGetVerbs = []verbTestCase{
 	{
-		args:          []string{"single-verb", "-test", "1"},
+		args:          []string{"prog", "single-verb", "-test", "1"},
 		expectedVerbs: []string{"single-verb"},
 		name:          "single verb",
 	},
 	{
-		args:          []string{"-test", "1"},
+		args:          []string{"prog", "-test", "1"},
 		expectedVerbs: []string{},
 		name:          "no verb",
 	},
 	{
-		args:          []string{"two-verb", "two-second-verb", "-test", "1"},
+		args:          []string{"prog", "two-verb", "two-second-verb", "-test", "1"},
 		expectedVerbs: []string{"two-verb", "two-second-verb"},
 		name:          "two verbs",
 	},
@@ -39,7 +39,7 @@ func TestGetVerbs(t *testing.T) {
 	for _, testCase := range testCasesGetVerbs {
 		t.Run(testCase.name, func(t *testing.T) {
 			os.Args = testCase.args
-			flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+			flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
 			t.Logf("%#v", os.Args)
 			Parse()
 			result := GetVerbs()
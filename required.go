@@ -0,0 +1,140 @@
+package flag
+
+import (
+	f "flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+var (
+	requiredFlags     = map[string]bool{}
+	mutuallyExclusive [][]string
+	requiresAllDeps   = map[string][]string{}
+)
+
+// MarkRequired marks name as required. Parse fails with a "required flag ... not provided"
+// message if it ends up unset after stdlib parsing, the environment, and the config file
+// have all been consulted.
+func MarkRequired(name string) {
+	canonical, _ := splitNames(name)
+	requiredFlags[canonical] = true
+}
+
+// MutuallyExclusive records that at most one of names may be set when Parse runs.
+func MutuallyExclusive(names ...string) {
+	mutuallyExclusive = append(mutuallyExclusive, names)
+}
+
+// RequiresAll records that, if name is set, every flag in deps must be set too.
+func RequiresAll(name string, deps ...string) {
+	requiresAllDeps[name] = append(requiresAllDeps[name], deps...)
+}
+
+// RequiredString defines a required string flag; see String and MarkRequired.
+func RequiredString(name string, value string, usage string) *string {
+	p := String(name, value, usage)
+	MarkRequired(name)
+	return p
+}
+
+// RequiredInt defines a required int flag; see Int and MarkRequired.
+func RequiredInt(name string, value int, usage string) *int {
+	p := Int(name, value, usage)
+	MarkRequired(name)
+	return p
+}
+
+// RequiredInt64 defines a required int64 flag; see Int64 and MarkRequired.
+func RequiredInt64(name string, value int64, usage string) *int64 {
+	p := Int64(name, value, usage)
+	MarkRequired(name)
+	return p
+}
+
+// RequiredUint defines a required uint flag; see Uint and MarkRequired.
+func RequiredUint(name string, value uint, usage string) *uint {
+	p := Uint(name, value, usage)
+	MarkRequired(name)
+	return p
+}
+
+// RequiredUint64 defines a required uint64 flag; see Uint64 and MarkRequired.
+func RequiredUint64(name string, value uint64, usage string) *uint64 {
+	p := Uint64(name, value, usage)
+	MarkRequired(name)
+	return p
+}
+
+// RequiredFloat64 defines a required float64 flag; see Float64 and MarkRequired.
+func RequiredFloat64(name string, value float64, usage string) *float64 {
+	p := Float64(name, value, usage)
+	MarkRequired(name)
+	return p
+}
+
+// RequiredDuration defines a required time.Duration flag; see Duration and MarkRequired.
+func RequiredDuration(name string, value time.Duration, usage string) *time.Duration {
+	p := Duration(name, value, usage)
+	MarkRequired(name)
+	return p
+}
+
+// isProvided reports whether name was set on the command line (under its canonical name or
+// any of its aliases), via its environment variable, or via the config file.
+func isProvided(setOnCommandLine map[string]bool, name string) bool {
+	if setOnCommandLine[name] {
+		return true
+	}
+	for _, alias := range flagAliases[name] {
+		if setOnCommandLine[alias] {
+			return true
+		}
+	}
+	if _, found := os.LookupEnv(envNameForFlagName(name)); found {
+		return true
+	}
+	if _, found := configValues[name]; found {
+		return true
+	}
+	return false
+}
+
+// validateConstraints checks the required, mutually-exclusive, and requires-all
+// constraints registered via MarkRequired, MutuallyExclusive, and RequiresAll.
+func validateConstraints() error {
+	setOnCommandLine := map[string]bool{}
+	f.Visit(func(fl *f.Flag) { setOnCommandLine[fl.Name] = true })
+
+	for name := range requiredFlags {
+		if !isProvided(setOnCommandLine, name) {
+			return fmt.Errorf("required flag -%s not provided", name)
+		}
+	}
+
+	for _, group := range mutuallyExclusive {
+		var set []string
+		for _, name := range group {
+			if isProvided(setOnCommandLine, name) {
+				set = append(set, "-"+name)
+			}
+		}
+		if len(set) > 1 {
+			return fmt.Errorf("flags %s are mutually exclusive", strings.Join(set, ", "))
+		}
+	}
+
+	for name, deps := range requiresAllDeps {
+		if !isProvided(setOnCommandLine, name) {
+			continue
+		}
+		for _, dep := range deps {
+			if !isProvided(setOnCommandLine, dep) {
+				return fmt.Errorf("flag -%s requires -%s to be set", name, dep)
+			}
+		}
+	}
+
+	return nil
+}
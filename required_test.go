@@ -0,0 +1,103 @@
+package flag
+
+import (
+	"flag"
+	"os"
+	"testing"
+)
+
+func resetRequiredState() {
+	requiredFlags = map[string]bool{}
+	mutuallyExclusive = nil
+	requiresAllDeps = map[string][]string{}
+}
+
+func TestRequiredFlagMissingFailsValidation(t *testing.T) {
+	oldArgs := os.Args
+	defer func() { os.Args = oldArgs }()
+	resetRequiredState()
+	defer resetRequiredState()
+	resetAliasState()
+	resetConfigState()
+	SetEnvPrefix("")
+
+	os.Args = []string{"prog"}
+	flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+
+	RequiredString("host", "", "a host name")
+	if err := flag.CommandLine.Parse(os.Args[1:]); err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+
+	if err := validateConstraints(); err == nil {
+		t.Fatalf("expected validation error when required flag is missing")
+	}
+}
+
+func TestRequiredFlagProvidedViaAliasPassesValidation(t *testing.T) {
+	oldArgs := os.Args
+	defer func() { os.Args = oldArgs }()
+	resetRequiredState()
+	defer resetRequiredState()
+	resetAliasState()
+	resetConfigState()
+	SetEnvPrefix("")
+
+	os.Args = []string{"prog", "-h", "myhost"}
+	flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+
+	RequiredString("host,h", "", "a host name")
+	Parse()
+
+	if err := validateConstraints(); err != nil {
+		t.Fatalf("expected no validation error, got %v", err)
+	}
+}
+
+func TestMutuallyExclusiveRejectsBothSet(t *testing.T) {
+	oldArgs := os.Args
+	defer func() { os.Args = oldArgs }()
+	resetRequiredState()
+	defer resetRequiredState()
+	resetAliasState()
+	resetConfigState()
+	SetEnvPrefix("")
+
+	os.Args = []string{"prog", "-a=1", "-b=2"}
+	flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+
+	String("a", "", "option a")
+	String("b", "", "option b")
+	MutuallyExclusive("a", "b")
+	if err := flag.CommandLine.Parse(os.Args[1:]); err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+
+	if err := validateConstraints(); err == nil {
+		t.Fatalf("expected validation error when mutually exclusive flags are both set")
+	}
+}
+
+func TestRequiresAllFailsWhenDependencyMissing(t *testing.T) {
+	oldArgs := os.Args
+	defer func() { os.Args = oldArgs }()
+	resetRequiredState()
+	defer resetRequiredState()
+	resetAliasState()
+	resetConfigState()
+	SetEnvPrefix("")
+
+	os.Args = []string{"prog", "-a=1"}
+	flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+
+	String("a", "", "option a")
+	String("b", "", "option b")
+	RequiresAll("a", "b")
+	if err := flag.CommandLine.Parse(os.Args[1:]); err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+
+	if err := validateConstraints(); err == nil {
+		t.Fatalf("expected validation error when a required dependency is missing")
+	}
+}
@@ -0,0 +1,436 @@
+package flag
+
+import (
+	f "flag"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// stringSliceValue implements flag.Value for a flag that can be repeated
+// (-tag foo -tag bar) and/or given as a comma-separated list (-tag foo,bar).
+type stringSliceValue struct {
+	value      *[]string
+	hasBeenSet bool
+}
+
+func newStringSliceValue(val []string, p *[]string) *stringSliceValue {
+	*p = val
+	return &stringSliceValue{value: p}
+}
+
+func (s *stringSliceValue) Set(val string) error {
+	if !s.hasBeenSet {
+		*s.value = nil
+		s.hasBeenSet = true
+	}
+	*s.value = append(*s.value, ListFromString(val)...)
+	return nil
+}
+
+func (s *stringSliceValue) String() string {
+	if s.value == nil {
+		return ""
+	}
+	return strings.Join(*s.value, ",")
+}
+
+// stringSliceFromConfig returns the comma-split config-file value for name. If not found, returns value.
+func stringSliceFromConfig(name string, value []string) []string {
+	ensureConfigLoaded()
+	val, found := configValues[name]
+	if !found {
+		return value
+	}
+	return ListFromString(val)
+}
+
+// stringSliceFromEnv returns the comma-split environment variable value for name. If not found, returns value.
+func stringSliceFromEnv(name string, value []string) []string {
+	val, found := os.LookupEnv(envNameForFlagName(name))
+	if !found {
+		return value
+	}
+	return ListFromString(val)
+}
+
+// StringSlice defines a repeatable string flag with specified name, default value, and usage string.
+// The flag may be given multiple times (-tag foo -tag bar) or as a comma-separated list (-tag foo,bar).
+// The return value is the address of a []string variable that stores the value of the flag.
+func StringSlice(name string, value []string, usage string) *[]string {
+	p := new([]string)
+	StringSliceVar(p, name, value, usage)
+	return p
+}
+
+// StringSliceVar defines a repeatable string flag with specified name, default value, and usage string.
+// The argument p points to a []string variable in which to store the value of the flag.
+func StringSliceVar(p *[]string, name string, value []string, usage string) {
+	def := stringSliceFromEnv(name, stringSliceFromConfig(name, value))
+	f.Var(newStringSliceValue(def, p), name, usage)
+}
+
+// intSliceValue implements flag.Value for a repeatable int flag.
+type intSliceValue struct {
+	value      *[]int
+	hasBeenSet bool
+}
+
+func newIntSliceValue(val []int, p *[]int) *intSliceValue {
+	*p = val
+	return &intSliceValue{value: p}
+}
+
+func (s *intSliceValue) Set(val string) error {
+	parsed := make([]int, 0, len(ListFromString(val)))
+	for _, part := range ListFromString(val) {
+		i, err := strconv.ParseInt(strings.TrimSpace(part), 0, 64)
+		if err != nil {
+			return err
+		}
+		parsed = append(parsed, int(i))
+	}
+	if !s.hasBeenSet {
+		*s.value = nil
+		s.hasBeenSet = true
+	}
+	*s.value = append(*s.value, parsed...)
+	return nil
+}
+
+func (s *intSliceValue) String() string {
+	if s.value == nil {
+		return ""
+	}
+	parts := make([]string, 0, len(*s.value))
+	for _, v := range *s.value {
+		parts = append(parts, strconv.Itoa(v))
+	}
+	return strings.Join(parts, ",")
+}
+
+// intSliceFromEnv returns the parsed, comma-split environment variable value for name.
+// On error, or if not found, returns value.
+func intSliceFromEnv(name string, value []int) []int {
+	val, found := os.LookupEnv(envNameForFlagName(name))
+	if !found {
+		return value
+	}
+	parsed := make([]int, 0, len(ListFromString(val)))
+	for _, part := range ListFromString(val) {
+		i, err := strconv.ParseInt(strings.TrimSpace(part), 0, 64)
+		if err != nil {
+			return value
+		}
+		parsed = append(parsed, int(i))
+	}
+	return parsed
+}
+
+// intSliceFromConfig returns the parsed, comma-split config-file value for name.
+// On error, or if not found, returns value.
+func intSliceFromConfig(name string, value []int) []int {
+	ensureConfigLoaded()
+	val, found := configValues[name]
+	if !found {
+		return value
+	}
+	parsed := make([]int, 0, len(ListFromString(val)))
+	for _, part := range ListFromString(val) {
+		i, err := strconv.ParseInt(strings.TrimSpace(part), 0, 64)
+		if err != nil {
+			return value
+		}
+		parsed = append(parsed, int(i))
+	}
+	return parsed
+}
+
+// IntSlice defines a repeatable int flag with specified name, default value, and usage string.
+// The return value is the address of a []int variable that stores the value of the flag.
+func IntSlice(name string, value []int, usage string) *[]int {
+	p := new([]int)
+	IntSliceVar(p, name, value, usage)
+	return p
+}
+
+// IntSliceVar defines a repeatable int flag with specified name, default value, and usage string.
+// The argument p points to a []int variable in which to store the value of the flag.
+func IntSliceVar(p *[]int, name string, value []int, usage string) {
+	def := intSliceFromEnv(name, intSliceFromConfig(name, value))
+	f.Var(newIntSliceValue(def, p), name, usage)
+}
+
+// int64SliceValue implements flag.Value for a repeatable int64 flag.
+type int64SliceValue struct {
+	value      *[]int64
+	hasBeenSet bool
+}
+
+func newInt64SliceValue(val []int64, p *[]int64) *int64SliceValue {
+	*p = val
+	return &int64SliceValue{value: p}
+}
+
+func (s *int64SliceValue) Set(val string) error {
+	parsed := make([]int64, 0, len(ListFromString(val)))
+	for _, part := range ListFromString(val) {
+		i, err := strconv.ParseInt(strings.TrimSpace(part), 0, 64)
+		if err != nil {
+			return err
+		}
+		parsed = append(parsed, i)
+	}
+	if !s.hasBeenSet {
+		*s.value = nil
+		s.hasBeenSet = true
+	}
+	*s.value = append(*s.value, parsed...)
+	return nil
+}
+
+func (s *int64SliceValue) String() string {
+	if s.value == nil {
+		return ""
+	}
+	parts := make([]string, 0, len(*s.value))
+	for _, v := range *s.value {
+		parts = append(parts, strconv.FormatInt(v, 10))
+	}
+	return strings.Join(parts, ",")
+}
+
+// int64SliceFromEnv returns the parsed, comma-split environment variable value for name.
+// On error, or if not found, returns value.
+func int64SliceFromEnv(name string, value []int64) []int64 {
+	val, found := os.LookupEnv(envNameForFlagName(name))
+	if !found {
+		return value
+	}
+	parsed := make([]int64, 0, len(ListFromString(val)))
+	for _, part := range ListFromString(val) {
+		i, err := strconv.ParseInt(strings.TrimSpace(part), 0, 64)
+		if err != nil {
+			return value
+		}
+		parsed = append(parsed, i)
+	}
+	return parsed
+}
+
+// int64SliceFromConfig returns the parsed, comma-split config-file value for name.
+// On error, or if not found, returns value.
+func int64SliceFromConfig(name string, value []int64) []int64 {
+	ensureConfigLoaded()
+	val, found := configValues[name]
+	if !found {
+		return value
+	}
+	parsed := make([]int64, 0, len(ListFromString(val)))
+	for _, part := range ListFromString(val) {
+		i, err := strconv.ParseInt(strings.TrimSpace(part), 0, 64)
+		if err != nil {
+			return value
+		}
+		parsed = append(parsed, i)
+	}
+	return parsed
+}
+
+// Int64Slice defines a repeatable int64 flag with specified name, default value, and usage string.
+// The return value is the address of a []int64 variable that stores the value of the flag.
+func Int64Slice(name string, value []int64, usage string) *[]int64 {
+	p := new([]int64)
+	Int64SliceVar(p, name, value, usage)
+	return p
+}
+
+// Int64SliceVar defines a repeatable int64 flag with specified name, default value, and usage string.
+// The argument p points to a []int64 variable in which to store the value of the flag.
+func Int64SliceVar(p *[]int64, name string, value []int64, usage string) {
+	def := int64SliceFromEnv(name, int64SliceFromConfig(name, value))
+	f.Var(newInt64SliceValue(def, p), name, usage)
+}
+
+// float64SliceValue implements flag.Value for a repeatable float64 flag.
+type float64SliceValue struct {
+	value      *[]float64
+	hasBeenSet bool
+}
+
+func newFloat64SliceValue(val []float64, p *[]float64) *float64SliceValue {
+	*p = val
+	return &float64SliceValue{value: p}
+}
+
+func (s *float64SliceValue) Set(val string) error {
+	parsed := make([]float64, 0, len(ListFromString(val)))
+	for _, part := range ListFromString(val) {
+		fl, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return err
+		}
+		parsed = append(parsed, fl)
+	}
+	if !s.hasBeenSet {
+		*s.value = nil
+		s.hasBeenSet = true
+	}
+	*s.value = append(*s.value, parsed...)
+	return nil
+}
+
+func (s *float64SliceValue) String() string {
+	if s.value == nil {
+		return ""
+	}
+	parts := make([]string, 0, len(*s.value))
+	for _, v := range *s.value {
+		parts = append(parts, strconv.FormatFloat(v, 'g', -1, 64))
+	}
+	return strings.Join(parts, ",")
+}
+
+// float64SliceFromEnv returns the parsed, comma-split environment variable value for name.
+// On error, or if not found, returns value.
+func float64SliceFromEnv(name string, value []float64) []float64 {
+	val, found := os.LookupEnv(envNameForFlagName(name))
+	if !found {
+		return value
+	}
+	parsed := make([]float64, 0, len(ListFromString(val)))
+	for _, part := range ListFromString(val) {
+		fl, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return value
+		}
+		parsed = append(parsed, fl)
+	}
+	return parsed
+}
+
+// float64SliceFromConfig returns the parsed, comma-split config-file value for name.
+// On error, or if not found, returns value.
+func float64SliceFromConfig(name string, value []float64) []float64 {
+	ensureConfigLoaded()
+	val, found := configValues[name]
+	if !found {
+		return value
+	}
+	parsed := make([]float64, 0, len(ListFromString(val)))
+	for _, part := range ListFromString(val) {
+		fl, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return value
+		}
+		parsed = append(parsed, fl)
+	}
+	return parsed
+}
+
+// Float64Slice defines a repeatable float64 flag with specified name, default value, and usage string.
+// The return value is the address of a []float64 variable that stores the value of the flag.
+func Float64Slice(name string, value []float64, usage string) *[]float64 {
+	p := new([]float64)
+	Float64SliceVar(p, name, value, usage)
+	return p
+}
+
+// Float64SliceVar defines a repeatable float64 flag with specified name, default value, and usage string.
+// The argument p points to a []float64 variable in which to store the value of the flag.
+func Float64SliceVar(p *[]float64, name string, value []float64, usage string) {
+	def := float64SliceFromEnv(name, float64SliceFromConfig(name, value))
+	f.Var(newFloat64SliceValue(def, p), name, usage)
+}
+
+// durationSliceValue implements flag.Value for a repeatable time.Duration flag.
+type durationSliceValue struct {
+	value      *[]time.Duration
+	hasBeenSet bool
+}
+
+func newDurationSliceValue(val []time.Duration, p *[]time.Duration) *durationSliceValue {
+	*p = val
+	return &durationSliceValue{value: p}
+}
+
+func (s *durationSliceValue) Set(val string) error {
+	parsed := make([]time.Duration, 0, len(ListFromString(val)))
+	for _, part := range ListFromString(val) {
+		dur, err := time.ParseDuration(strings.TrimSpace(part))
+		if err != nil {
+			return err
+		}
+		parsed = append(parsed, dur)
+	}
+	if !s.hasBeenSet {
+		*s.value = nil
+		s.hasBeenSet = true
+	}
+	*s.value = append(*s.value, parsed...)
+	return nil
+}
+
+func (s *durationSliceValue) String() string {
+	if s.value == nil {
+		return ""
+	}
+	parts := make([]string, 0, len(*s.value))
+	for _, v := range *s.value {
+		parts = append(parts, v.String())
+	}
+	return strings.Join(parts, ",")
+}
+
+// durationSliceFromEnv returns the parsed, comma-split environment variable value for name.
+// On error, or if not found, returns value.
+func durationSliceFromEnv(name string, value []time.Duration) []time.Duration {
+	val, found := os.LookupEnv(envNameForFlagName(name))
+	if !found {
+		return value
+	}
+	parsed := make([]time.Duration, 0, len(ListFromString(val)))
+	for _, part := range ListFromString(val) {
+		dur, err := time.ParseDuration(strings.TrimSpace(part))
+		if err != nil {
+			return value
+		}
+		parsed = append(parsed, dur)
+	}
+	return parsed
+}
+
+// durationSliceFromConfig returns the parsed, comma-split config-file value for name.
+// On error, or if not found, returns value.
+func durationSliceFromConfig(name string, value []time.Duration) []time.Duration {
+	ensureConfigLoaded()
+	val, found := configValues[name]
+	if !found {
+		return value
+	}
+	parsed := make([]time.Duration, 0, len(ListFromString(val)))
+	for _, part := range ListFromString(val) {
+		dur, err := time.ParseDuration(strings.TrimSpace(part))
+		if err != nil {
+			return value
+		}
+		parsed = append(parsed, dur)
+	}
+	return parsed
+}
+
+// DurationSlice defines a repeatable time.Duration flag with specified name, default value, and usage string.
+// The return value is the address of a []time.Duration variable that stores the value of the flag.
+func DurationSlice(name string, value []time.Duration, usage string) *[]time.Duration {
+	p := new([]time.Duration)
+	DurationSliceVar(p, name, value, usage)
+	return p
+}
+
+// DurationSliceVar defines a repeatable time.Duration flag with specified name, default value, and usage string.
+// The argument p points to a []time.Duration variable in which to store the value of the flag.
+func DurationSliceVar(p *[]time.Duration, name string, value []time.Duration, usage string) {
+	def := durationSliceFromEnv(name, durationSliceFromConfig(name, value))
+	f.Var(newDurationSliceValue(def, p), name, usage)
+}
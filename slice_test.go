@@ -0,0 +1,109 @@
+package flag
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestStringSliceAcceptsRepeatedAndCSVForms(t *testing.T) {
+	oldArgs := os.Args
+	defer func() { os.Args = oldArgs }()
+	resetConfigState()
+	SetEnvPrefix("")
+
+	os.Args = []string{"prog", "-tag", "a,b", "-tag", "c"}
+	flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+
+	p := StringSlice("tag", nil, "a repeatable tag")
+	Parse()
+
+	want := []string{"a", "b", "c"}
+	if len(*p) != len(want) {
+		t.Fatalf("expected %v, got %v", want, *p)
+	}
+	for i, v := range want {
+		if (*p)[i] != v {
+			t.Fatalf("expected %v, got %v", want, *p)
+		}
+	}
+}
+
+func TestIntSliceFromEnv(t *testing.T) {
+	oldArgs := os.Args
+	defer func() { os.Args = oldArgs }()
+	resetConfigState()
+	SetEnvPrefix("")
+
+	os.Setenv("COUNTS", "1,2,3")
+	defer os.Unsetenv("COUNTS")
+	os.Args = []string{"prog"}
+	flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+
+	p := IntSlice("counts", nil, "a repeatable count")
+	Parse()
+
+	want := []int{1, 2, 3}
+	if len(*p) != len(want) {
+		t.Fatalf("expected %v, got %v", want, *p)
+	}
+	for i, v := range want {
+		if (*p)[i] != v {
+			t.Fatalf("expected %v, got %v", want, *p)
+		}
+	}
+}
+
+func TestDurationSliceConfigSeedsDefault(t *testing.T) {
+	oldArgs := os.Args
+	defer func() { os.Args = oldArgs }()
+	resetConfigState()
+	SetEnvPrefix("")
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.ini")
+	if err := os.WriteFile(path, []byte("intervals = 1s,2s\n"), 0o644); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+
+	os.Args = []string{"prog", "-config=" + path}
+	flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+
+	p := DurationSlice("intervals", nil, "a repeatable interval")
+	Parse()
+
+	want := []time.Duration{time.Second, 2 * time.Second}
+	if len(*p) != len(want) {
+		t.Fatalf("expected %v, got %v", want, *p)
+	}
+	for i, v := range want {
+		if (*p)[i] != v {
+			t.Fatalf("expected %v, got %v", want, *p)
+		}
+	}
+}
+
+func TestFloat64SliceCommandLineOverridesConfig(t *testing.T) {
+	oldArgs := os.Args
+	defer func() { os.Args = oldArgs }()
+	resetConfigState()
+	SetEnvPrefix("")
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.ini")
+	if err := os.WriteFile(path, []byte("ratios = 0.1,0.2\n"), 0o644); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+
+	os.Args = []string{"prog", "-config=" + path, "-ratios=0.9"}
+	flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+
+	p := Float64Slice("ratios", nil, "a repeatable ratio")
+	Parse()
+
+	if len(*p) != 1 || (*p)[0] != 0.9 {
+		t.Fatalf("expected command-line value to win, got %v", *p)
+	}
+}
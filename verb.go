@@ -0,0 +1,202 @@
+package flag
+
+import (
+	f "flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// verbs holds the top-level verbs registered via RegisterVerb.
+var verbs = map[string]*Verb{}
+
+// Verb represents a subcommand with its own FlagSet, optional nested verbs, and
+// an Action that is invoked once flags have been parsed. Verb-scoped flag
+// definers (Bool, String, ...) have the package-level ones' signatures and fall
+// back to an environment variable namespaced by the verb's position in the verb
+// tree, e.g. a flag "timeout" on a verb "build" resolves to MYAPP_BUILD_TIMEOUT.
+// They do not read the config file and do not accept comma-delimited alias names.
+type Verb struct {
+	Name    string
+	Usage   string
+	FlagSet *f.FlagSet
+	Action  func(args []string) error
+
+	parent *Verb
+	verbs  map[string]*Verb
+}
+
+// NewVerb creates a Verb with its own FlagSet, ready for flag definitions and
+// nested verb registration.
+func NewVerb(name, usage string) *Verb {
+	return &Verb{
+		Name:    name,
+		Usage:   usage,
+		FlagSet: f.NewFlagSet(name, f.ExitOnError),
+		verbs:   map[string]*Verb{},
+	}
+}
+
+// RegisterVerb registers v as a top-level verb dispatched by Run.
+func RegisterVerb(v *Verb) {
+	verbs[v.Name] = v
+}
+
+// RegisterVerb registers child as a verb nested below v, dispatched when v is
+// followed by child.Name on the command line.
+func (v *Verb) RegisterVerb(child *Verb) {
+	child.parent = v
+	v.verbs[child.Name] = child
+}
+
+// envName returns name namespaced by the verb's position in the verb tree,
+// e.g. "timeout" on verb "build" nested under nothing becomes "build-timeout".
+func (v *Verb) envName(name string) string {
+	if v.parent == nil {
+		return fmt.Sprintf("%s-%s", v.Name, name)
+	}
+	return fmt.Sprintf("%s-%s", v.parent.envName(v.Name), name)
+}
+
+// Bool defines a bool flag on the verb, falling back to the verb-scoped environment variable.
+func (v *Verb) Bool(name string, value bool, usage string) *bool {
+	return v.FlagSet.Bool(name, boolFromEnv(v.envName(name), value), usage)
+}
+
+// BoolVar defines a bool flag on the verb, falling back to the verb-scoped environment variable.
+func (v *Verb) BoolVar(p *bool, name string, value bool, usage string) {
+	v.FlagSet.BoolVar(p, name, boolFromEnv(v.envName(name), value), usage)
+}
+
+// String defines a string flag on the verb, falling back to the verb-scoped environment variable.
+func (v *Verb) String(name string, value string, usage string) *string {
+	return v.FlagSet.String(name, stringFromEnv(v.envName(name), value), usage)
+}
+
+// StringVar defines a string flag on the verb, falling back to the verb-scoped environment variable.
+func (v *Verb) StringVar(p *string, name string, value string, usage string) {
+	v.FlagSet.StringVar(p, name, stringFromEnv(v.envName(name), value), usage)
+}
+
+// Int defines an int flag on the verb, falling back to the verb-scoped environment variable.
+func (v *Verb) Int(name string, value int, usage string) *int {
+	return v.FlagSet.Int(name, int(int64FromEnv(v.envName(name), int64(value))), usage)
+}
+
+// IntVar defines an int flag on the verb, falling back to the verb-scoped environment variable.
+func (v *Verb) IntVar(p *int, name string, value int, usage string) {
+	v.FlagSet.IntVar(p, name, int(int64FromEnv(v.envName(name), int64(value))), usage)
+}
+
+// Int64 defines an int64 flag on the verb, falling back to the verb-scoped environment variable.
+func (v *Verb) Int64(name string, value int64, usage string) *int64 {
+	return v.FlagSet.Int64(name, int64FromEnv(v.envName(name), value), usage)
+}
+
+// Int64Var defines an int64 flag on the verb, falling back to the verb-scoped environment variable.
+func (v *Verb) Int64Var(p *int64, name string, value int64, usage string) {
+	v.FlagSet.Int64Var(p, name, int64FromEnv(v.envName(name), value), usage)
+}
+
+// Uint defines a uint flag on the verb, falling back to the verb-scoped environment variable.
+func (v *Verb) Uint(name string, value uint, usage string) *uint {
+	return v.FlagSet.Uint(name, uint(uint64FromEnv(v.envName(name), uint64(value))), usage)
+}
+
+// UintVar defines a uint flag on the verb, falling back to the verb-scoped environment variable.
+func (v *Verb) UintVar(p *uint, name string, value uint, usage string) {
+	v.FlagSet.UintVar(p, name, uint(uint64FromEnv(v.envName(name), uint64(value))), usage)
+}
+
+// Uint64 defines a uint64 flag on the verb, falling back to the verb-scoped environment variable.
+func (v *Verb) Uint64(name string, value uint64, usage string) *uint64 {
+	return v.FlagSet.Uint64(name, uint64FromEnv(v.envName(name), value), usage)
+}
+
+// Uint64Var defines a uint64 flag on the verb, falling back to the verb-scoped environment variable.
+func (v *Verb) Uint64Var(p *uint64, name string, value uint64, usage string) {
+	v.FlagSet.Uint64Var(p, name, uint64FromEnv(v.envName(name), value), usage)
+}
+
+// Float64 defines a float64 flag on the verb, falling back to the verb-scoped environment variable.
+func (v *Verb) Float64(name string, value float64, usage string) *float64 {
+	return v.FlagSet.Float64(name, float64FromEnv(v.envName(name), value), usage)
+}
+
+// Float64Var defines a float64 flag on the verb, falling back to the verb-scoped environment variable.
+func (v *Verb) Float64Var(p *float64, name string, value float64, usage string) {
+	v.FlagSet.Float64Var(p, name, float64FromEnv(v.envName(name), value), usage)
+}
+
+// Duration defines a time.Duration flag on the verb, falling back to the verb-scoped environment variable.
+func (v *Verb) Duration(name string, value time.Duration, usage string) *time.Duration {
+	return v.FlagSet.Duration(name, durationFromEnv(v.envName(name), value), usage)
+}
+
+// DurationVar defines a time.Duration flag on the verb, falling back to the verb-scoped environment variable.
+func (v *Verb) DurationVar(p *time.Duration, name string, value time.Duration, usage string) {
+	v.FlagSet.DurationVar(p, name, durationFromEnv(v.envName(name), value), usage)
+}
+
+// PrintUsage prints the verb's usage line followed by its flag defaults.
+func (v *Verb) PrintUsage() {
+	fmt.Fprintf(os.Stderr, "%s: %s\n", v.Name, v.Usage)
+	v.FlagSet.PrintDefaults()
+}
+
+// run descends into a nested verb if args[0] names one registered under v; otherwise it
+// stops descending and treats all of args, including a leading non-flag token, as belonging
+// to this verb: it parses args against the verb's own FlagSet and invokes Action with
+// whatever FlagSet.Parse leaves as positional arguments.
+func (v *Verb) run(args []string) error {
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		if child, ok := v.verbs[args[0]]; ok {
+			return child.run(args[1:])
+		}
+	}
+	if v.FlagSet != nil {
+		if err := v.FlagSet.Parse(args); err != nil {
+			return err
+		}
+		args = v.FlagSet.Args()
+	}
+	if v.Action == nil {
+		v.PrintUsage()
+		return fmt.Errorf("verb %q has no action", v.Name)
+	}
+	return v.Action(args)
+}
+
+// GetVerbs returns the leading arguments of os.Args (after the program name)
+// that do not look like flags, i.e. the chain of verbs a caller intends to
+// dispatch to. It stops at the first argument starting with "-".
+func GetVerbs() []string {
+	result := make([]string, 0)
+	for _, arg := range os.Args[1:] {
+		if strings.HasPrefix(arg, "-") {
+			break
+		}
+		result = append(result, arg)
+	}
+	return result
+}
+
+// Run resolves the verb named in os.Args[1] against the registered top-level verbs and
+// dispatches to it, descending through any nested verbs before parsing flags and invoking
+// the leaf verb's Action. It returns an error if no verb was given or os.Args[1] does not
+// name a registered top-level verb; a later argument that names no nested verb is passed
+// to the deepest matched verb as a positional argument.
+func Run() error {
+	args := os.Args[1:]
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		Usage()
+		return fmt.Errorf("no verb given")
+	}
+	v, ok := verbs[args[0]]
+	if !ok {
+		Usage()
+		return fmt.Errorf("unknown verb %q", args[0])
+	}
+	return v.run(args[1:])
+}
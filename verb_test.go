@@ -0,0 +1,56 @@
+package flag
+
+import (
+	"os"
+	"testing"
+)
+
+func resetVerbState() {
+	verbs = map[string]*Verb{}
+}
+
+func TestRunPassesPositionalArgsToLeafVerb(t *testing.T) {
+	oldArgs := os.Args
+	defer func() { os.Args = oldArgs }()
+	resetVerbState()
+
+	var got []string
+	v := NewVerb("build", "builds a thing")
+	v.Action = func(args []string) error {
+		got = args
+		return nil
+	}
+	RegisterVerb(v)
+
+	os.Args = []string{"prog", "build", "myfile.txt"}
+	if err := Run(); err != nil {
+		t.Fatalf("Run() returned error: %v", err)
+	}
+	if len(got) != 1 || got[0] != "myfile.txt" {
+		t.Fatalf("expected Action to receive [myfile.txt], got %#v", got)
+	}
+}
+
+func TestRunDispatchesNestedVerb(t *testing.T) {
+	oldArgs := os.Args
+	defer func() { os.Args = oldArgs }()
+	resetVerbState()
+
+	var got []string
+	parent := NewVerb("remote", "manage remotes")
+	child := NewVerb("add", "add a remote")
+	child.Action = func(args []string) error {
+		got = args
+		return nil
+	}
+	parent.RegisterVerb(child)
+	RegisterVerb(parent)
+
+	os.Args = []string{"prog", "remote", "add", "origin"}
+	if err := Run(); err != nil {
+		t.Fatalf("Run() returned error: %v", err)
+	}
+	if len(got) != 1 || got[0] != "origin" {
+		t.Fatalf("expected nested Action to receive [origin], got %#v", got)
+	}
+}